@@ -0,0 +1,38 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cerrortest provides test helpers for the cerror package
+package cerrortest
+
+import (
+	"testing"
+
+	"github.com/lsytj0413/ena/cerror"
+)
+
+// AssertCode asserts that err is (or wraps) a cerror.Error with the errorCode,
+// otherwise it marks the test failed with the actual code and message
+func AssertCode(t testing.TB, err error, errorCode int) {
+	t.Helper()
+
+	e, ok := cerror.AsError(err)
+	if !ok {
+		t.Errorf("expect cerror.Error with errorCode %d, got %T: %v", errorCode, err, err)
+		return
+	}
+
+	if !e.Is(errorCode) {
+		t.Errorf("expect cerror.Error with errorCode %d, got errorCode %d: %v", errorCode, e.ErrorCode, e)
+	}
+}
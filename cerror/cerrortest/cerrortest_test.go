@@ -0,0 +1,83 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerrortest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lsytj0413/ena/cerror"
+)
+
+type fakeTB struct {
+	testing.TB
+
+	messages []string
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Errorf(format string, args ...interface{}) {
+	t.messages = append(t.messages, fmt.Sprintf(format, args...))
+}
+
+type cerrortestTestSuite struct {
+	suite.Suite
+}
+
+func (s *cerrortestTestSuite) TestAssertCodeOk() {
+	t := &fakeTB{}
+	AssertCode(t, cerror.NewError(100, "TestAssertCodeOk"), 100)
+	AssertCode(t, fmt.Errorf("wrapped: %w", cerror.NewError(100, "TestAssertCodeOk")), 100)
+	s.Empty(t.messages)
+}
+
+func (s *cerrortestTestSuite) TestAssertCodeFailed() {
+	type testCase struct {
+		description string
+		err         error
+		target      string
+	}
+	testCases := []testCase{
+		{
+			description: "errorCode doesn't match",
+			err:         cerror.Newf(200, "mismatch"),
+			target:      "expect cerror.Error with errorCode 100, got errorCode 200: mismatch ()",
+		},
+		{
+			description: "not cerror.Error",
+			err:         errors.New("plain"),
+			target:      "expect cerror.Error with errorCode 100, got *errors.errorString: plain",
+		},
+		{
+			description: "nil error",
+			err:         nil,
+			target:      "expect cerror.Error with errorCode 100, got <nil>: <nil>",
+		},
+	}
+	for _, tc := range testCases {
+		t := &fakeTB{}
+		AssertCode(t, tc.err, 100)
+		s.Equal([]string{tc.target}, t.messages, tc.description)
+	}
+}
+
+func TestCerrortestTestSuite(t *testing.T) {
+	s := &cerrortestTestSuite{}
+	suite.Run(t, s)
+}
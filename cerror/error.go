@@ -17,8 +17,12 @@ package cerror
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Error is store package error message define
@@ -26,16 +30,58 @@ type Error struct {
 	ErrorCode int    `json:"errorCode"`
 	Message   string `json:"message"`
 	Cause     string `json:"cause,omitempty"`
+	// Time is when the error is constructed, it's zero unless the clock is set by SetClock
+	Time time.Time `json:"-"`
+	// PublicMessage is the message safe for clients, it replaces Message and Cause in JSONStringPublic
+	PublicMessage string `json:"-"`
+
+	// private marks the error as internal, only the ErrorCode is exposed to clients
+	private bool
+	// origin is the error this one is derived from, see Sanitize
+	origin error
 }
 
 var errorsMessage = map[int]string{}
 
+// errorsPublicMessage is the generic public message of errorCode, it's used when
+// the Error doesn't have PublicMessage
+var errorsPublicMessage = map[int]string{}
+
+// clock returns the construct time of Error, nil means don't record it
+var clock func() time.Time
+
+// SetClock replace the clock used to set Error.Time, such as SetClock(time.Now). The
+// clock is nil by default, so the Time is zero and omitted from JSON unless it's set.
+// Set nil clock will disable the Time of new constructed Error.
+func SetClock(c func() time.Time) {
+	clock = c
+}
+
+func now() time.Time {
+	if clock == nil {
+		return time.Time{}
+	}
+
+	return clock()
+}
+
 // NewError construct a Error struct and return it
 func NewError(errorCode int, cause string) *Error {
 	return &Error{
 		ErrorCode: errorCode,
 		Message:   errorsMessage[errorCode],
 		Cause:     cause,
+		Time:      now(),
+	}
+}
+
+// Newf construct a Error struct with the formatted Message, the errorCode doesn't
+// need a registered message
+func Newf(errorCode int, format string, args ...interface{}) *Error {
+	return &Error{
+		ErrorCode: errorCode,
+		Message:   fmt.Sprintf(format, args...),
+		Time:      now(),
 	}
 }
 
@@ -44,6 +90,81 @@ func (e Error) Error() string {
 	return e.Message + " (" + e.Cause + ")"
 }
 
+// WithTime returns a copy of the error with the Time, the zero t omits it from JSON
+func (e Error) WithTime(t time.Time) *Error {
+	e.Time = t
+	return &e
+}
+
+// AppendCause returns a copy of the error with more appended to the Cause, the sep
+// is only inserted when the Cause isn't empty
+func (e Error) AppendCause(sep string, more string) *Error {
+	if e.Cause == "" {
+		e.Cause = more
+	} else {
+		e.Cause = e.Cause + sep + more
+	}
+	return &e
+}
+
+// ToError returns e as error, and a true nil error if e is nil. Returning a nil *Error
+// as error results in a non-nil error interface holding a nil pointer, so functions
+// returning error should return the *Error by ToError.
+func (e *Error) ToError() error {
+	if e == nil {
+		return nil
+	}
+
+	return e
+}
+
+// goError is Error without methods, it's used to print the fields of Error
+type goError Error
+
+// Format is for the fmt.Formatter interface, %s, %v, %x, %X and %q print the Error
+// with the flags, width and precision, and %+v also prints the ErrorCode. %#v and the
+// other verbs print the fields of Error as fmt does for the struct.
+func (e Error) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "[%d] %s", e.ErrorCode, e.Error())
+			return
+		}
+		if f.Flag('#') {
+			break
+		}
+		fallthrough
+	case 's', 'x', 'X', 'q':
+		fmt.Fprintf(f, formatString(f, verb), e.Error())
+		return
+	}
+
+	// Error is the goError named as Error, so %#v prints the type as cerror.Error
+	type Error goError
+	fmt.Fprintf(f, formatString(f, verb), Error(e))
+}
+
+// formatString rebuilds the format directive from the fmt.State and verb
+func formatString(f fmt.State, verb rune) string {
+	b := strings.Builder{}
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		b.WriteString(strconv.Itoa(width))
+	}
+	if precision, ok := f.Precision(); ok {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(precision))
+	}
+	b.WriteRune(verb)
+	return b.String()
+}
+
 // Is check the ErrorCode is equal
 func (e Error) Is(errorCode int) bool {
 	return e.ErrorCode == errorCode
@@ -72,29 +193,187 @@ func IsError(err error) bool {
 	return ok
 }
 
+// Unwrap returns the error this one is derived from, it's nil for the constructed Error.
+// It has pointer receiver so errors.Is and errors.As are safe on a nil *Error.
+func (e *Error) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+
+	return e.origin
+}
+
+// Sanitize rewrites err for external clients: the errorCode of Error in err's chain is
+// replaced by the mapping, the unmapped errorCode and non Error err use fallback. The
+// Message is the registered one of the new errorCode. The internal fields (Cause,
+// PublicMessage and the public flag) of the original are always cleared, there is no
+// option to keep them: the result crosses a trust boundary, so nothing of the original
+// may leak by default. The original err is still available by Unwrap for internal
+// logging.
+func Sanitize(err error, mapping map[int]int, fallback int) *Error {
+	if err == nil {
+		return nil
+	}
+
+	errorCode := fallback
+	if e, ok := AsError(err); ok {
+		if c, ok := mapping[e.ErrorCode]; ok {
+			errorCode = c
+		}
+	}
+
+	return &Error{
+		ErrorCode: errorCode,
+		Message:   errorsMessage[errorCode],
+		Time:      now(),
+		origin:    err,
+	}
+}
+
+// ErrNotCError is returned by FromError when the error isn't an Error
+var ErrNotCError = errors.New("not a cerror.Error")
+
+// AsError finds the first Error in err's chain (via Unwrap), and returns it and true
+// if found. A nil *Error is treated as not found. It never returns ErrNotCError, use
+// FromError for the error flow.
+func AsError(err error) (*Error, bool) {
+	var e *Error
+	if !errors.As(err, &e) || e == nil {
+		return nil, false
+	}
+
+	return e, true
+}
+
+// FromError finds the first Error in err's chain like AsError. It returns nil error
+// when found or err is nil, otherwise it returns an error wrapping ErrNotCError with
+// the err message, which can be checked by errors.Is.
+func FromError(err error) (*Error, error) {
+	if err == nil {
+		return nil, nil
+	}
+
+	e, ok := AsError(err)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrNotCError, err)
+	}
+
+	return e, nil
+}
+
 var (
 	// For unittest
 	marshal func(interface{}) ([]byte, error)
 )
 
-// JSONString returns the JSON format message
+// timeLayout is the JSON format of Error.Time
+const timeLayout = time.RFC3339
+
+// errorJSON is the JSON view of Error, the Time is formatted as RFC3339 string and
+// omitted when it's zero
+type errorJSON struct {
+	ErrorCode int    `json:"errorCode"`
+	Message   string `json:"message"`
+	Cause     string `json:"cause,omitempty"`
+	Time      string `json:"time,omitempty"`
+}
+
+// JSONString returns the JSON format message, the Time is included as RFC3339 string
+// when it isn't zero
 func (e Error) JSONString() string {
-	b, err := marshal(e)
+	v := errorJSON{
+		ErrorCode: e.ErrorCode,
+		Message:   e.Message,
+		Cause:     e.Cause,
+	}
+	if !e.Time.IsZero() {
+		v.Time = e.Time.Format(timeLayout)
+	}
+
+	b, err := marshal(v)
 	if err != nil {
+		if e.Time.IsZero() {
+			return fmt.Sprintf(
+				`{"errorCode":%d,"message":"%s","cause":"%s"}`,
+				e.ErrorCode,
+				e.Message,
+				e.Cause)
+		}
+
 		return fmt.Sprintf(
-			`{"errorCode":%d,"message":"%s","cause":"%s"}`,
+			`{"errorCode":%d,"message":"%s","cause":"%s","time":"%s"}`,
 			e.ErrorCode,
 			e.Message,
-			e.Cause)
+			e.Cause,
+			e.Time.Format(timeLayout))
+	}
+
+	return string(b)
+}
+
+// WithPublic returns a copy of the error marked as public or not, the non-public
+// error only exposes the ErrorCode and public message by JSONStringPublic
+func (e Error) WithPublic(public bool) *Error {
+	e.private = !public
+	return &e
+}
+
+// IsPublic check the error can be exposed to clients with full detail
+func (e Error) IsPublic() bool {
+	return !e.private
+}
+
+// WithPublicMessage returns a copy of the error with the PublicMessage
+func (e Error) WithPublicMessage(message string) *Error {
+	e.PublicMessage = message
+	return &e
+}
+
+// JSONStringPublic returns the JSON format message for clients. The public error
+// without PublicMessage is the same as JSONString, otherwise the Message and Cause
+// are omitted and the message is the PublicMessage, or the generic public message of
+// the ErrorCode if PublicMessage is empty. Use JSONString for logs.
+func (e Error) JSONStringPublic() string {
+	if e.IsPublic() && e.PublicMessage == "" {
+		return e.JSONString()
+	}
+
+	message := e.PublicMessage
+	if message == "" {
+		message = errorsPublicMessage[e.ErrorCode]
+	}
+
+	b, err := marshal(struct {
+		ErrorCode int    `json:"errorCode"`
+		Message   string `json:"message,omitempty"`
+	}{
+		ErrorCode: e.ErrorCode,
+		Message:   message,
+	})
+	if err != nil {
+		if message == "" {
+			return fmt.Sprintf(`{"errorCode":%d}`, e.ErrorCode)
+		}
+		return fmt.Sprintf(`{"errorCode":%d,"message":"%s"}`, e.ErrorCode, message)
 	}
 
 	return string(b)
 }
 
-// SetErrorsMessage init error defined errorCode and Message
+// SetErrorsPublicMessage init error defined errorCode and generic public message
+func SetErrorsPublicMessage(message map[int]string) {
+	for k, v := range message {
+		errorsPublicMessage[k] = v
+	}
+}
+
+// SetErrorsMessage init error defined errorCode and Message. The last writer wins:
+// when a later call contains an errorCode already set, its message replaces the
+// earlier one.
 func SetErrorsMessage(message map[int]string) {
 	for k, v := range message {
 		errorsMessage[k] = v
+		recordRegistration(registeredMessages, k, v)
 	}
 }
 
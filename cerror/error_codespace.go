@@ -0,0 +1,59 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+// Codespace is a subsystem errorCode namespace, the local errorCode of subsystem
+// is offset by base into the global errorCode
+type Codespace struct {
+	name string
+	base int
+}
+
+// NewCodespace construct a Codespace with name and base
+func NewCodespace(name string, base int) *Codespace {
+	return &Codespace{
+		name: name,
+		base: base,
+	}
+}
+
+// Name returns the name of Codespace
+func (c *Codespace) Name() string {
+	return c.name
+}
+
+// Code returns the global errorCode of localCode
+func (c *Codespace) Code(localCode int) int {
+	return c.base + localCode
+}
+
+// SetErrorsMessage init error defined localCode and Message under the global errorCode
+func (c *Codespace) SetErrorsMessage(message map[int]string) {
+	global := make(map[int]string, len(message))
+	for k, v := range message {
+		global[c.Code(k)] = v
+	}
+	SetErrorsMessage(global)
+}
+
+// New construct a Error struct with the global errorCode of localCode
+func (c *Codespace) New(localCode int, cause string) *Error {
+	return NewError(c.Code(localCode), cause)
+}
+
+// Is check err is Error with the global errorCode of localCode
+func (c *Codespace) Is(err error, localCode int) bool {
+	return Is(err, c.Code(localCode))
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type errorCodespaceTestSuite struct {
+	suite.Suite
+
+	c *Codespace
+}
+
+func (s *errorCodespaceTestSuite) SetupTest() {
+	errorsMessage = map[int]string{}
+	s.c = NewCodespace("store", 10000000)
+	s.c.SetErrorsMessage(map[int]string{
+		1: "Target is Not File",
+		2: "Target is Not Dir",
+	})
+}
+
+func (s *errorCodespaceTestSuite) TearDownTest() {
+	errorsMessage = map[int]string{}
+}
+
+func (s *errorCodespaceTestSuite) TestCode() {
+	s.Equal("store", s.c.Name())
+	s.Equal(EcodeNotFile, s.c.Code(1))
+	s.Equal(EcodeNotDir, s.c.Code(2))
+}
+
+func (s *errorCodespaceTestSuite) TestSetErrorsMessage() {
+	s.Equal(2, len(errorsMessage))
+	s.Equal("Target is Not File", errorsMessage[EcodeNotFile])
+	s.Equal("Target is Not Dir", errorsMessage[EcodeNotDir])
+}
+
+func (s *errorCodespaceTestSuite) TestNew() {
+	e := s.c.New(2, "TestNew")
+	s.Equal(EcodeNotDir, e.ErrorCode)
+	s.Equal("Target is Not Dir", e.Message)
+	s.Equal("TestNew", e.Cause)
+}
+
+func (s *errorCodespaceTestSuite) TestIs() {
+	e := s.c.New(2, "TestIs")
+	s.True(s.c.Is(e, 2))
+	s.True(Is(e, EcodeNotDir))
+	s.False(s.c.Is(e, 1))
+	s.False(s.c.Is(NewError(2, ""), 2))
+	s.False(NewCodespace("other", 20000000).Is(e, 2))
+}
+
+func TestErrorCodespaceTestSuite(t *testing.T) {
+	s := &errorCodespaceTestSuite{}
+	suite.Run(t, s)
+}
@@ -15,7 +15,9 @@
 package cerror
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 )
 
 var errorsStatus = map[int]int{}
@@ -54,3 +56,73 @@ func SetErrorsStatus(status map[int]int) {
 		errorsStatus[k] = v
 	}
 }
+
+// problem is the RFC 7807 Problem Details document
+type problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     int    `json:"code,omitempty"`
+}
+
+// ProblemJSON returns the RFC 7807 problem+json document of err. For Error in err's
+// chain the title is the Message, the detail is the Cause and the status is from
+// StatusCode. The non-public Error or Error with PublicMessage only exposes the public
+// message as title. The title is the HTTP status text when there's no message. Other
+// errors become a 500 problem without detail, and nil err returns nil.
+func ProblemJSON(err error, instance string) []byte {
+	if err == nil {
+		return nil
+	}
+
+	p := problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusInternalServerError),
+		Status:   http.StatusInternalServerError,
+		Instance: instance,
+	}
+
+	if e, ok := AsError(err); ok {
+		p.Status = e.StatusCode()
+		p.Code = e.ErrorCode
+		p.Title = e.Message
+		p.Detail = e.Cause
+		if !e.IsPublic() || e.PublicMessage != "" {
+			p.Title = e.PublicMessage
+			if p.Title == "" {
+				p.Title = errorsPublicMessage[e.ErrorCode]
+			}
+			p.Detail = ""
+		}
+		if p.Title == "" {
+			p.Title = http.StatusText(p.Status)
+		}
+	}
+
+	b, err := marshal(p)
+	if err != nil {
+		return p.fallbackJSON()
+	}
+
+	return b
+}
+
+// fallbackJSON formats the problem with the same members as json.Marshal, it's used
+// when json.Marshal fails
+func (p problem) fallbackJSON() []byte {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, `{"type":%q,"title":%q,"status":%d`, p.Type, p.Title, p.Status)
+	if p.Detail != "" {
+		fmt.Fprintf(&b, `,"detail":%q`, p.Detail)
+	}
+	if p.Instance != "" {
+		fmt.Fprintf(&b, `,"instance":%q`, p.Instance)
+	}
+	if p.Code != 0 {
+		fmt.Fprintf(&b, `,"code":%d`, p.Code)
+	}
+	b.WriteByte('}')
+	return []byte(b.String())
+}
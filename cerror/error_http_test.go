@@ -15,6 +15,9 @@
 package cerror
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -87,6 +90,149 @@ func (s *errorHTTPTestSuite) TestWriteToOk() {
 
 	s.Equal(err.StatusCode(), w.header)
 	s.Equal(err.JSONString()+"\n", string(w.body))
+	s.NotContains(string(w.body), "time")
+}
+
+func (s *errorHTTPTestSuite) TestProblemJSON() {
+	errorsMessage = map[int]string{
+		EcodeNotExists: "Target is not exists",
+		EcodeExists:    "Target is exists",
+	}
+	errorsStatus = map[int]int{
+		EcodeNotExists: http.StatusNotFound,
+		EcodeExists:    http.StatusConflict,
+		EcodeUnknown:   http.StatusServiceUnavailable,
+	}
+	defer func() {
+		errorsMessage = map[int]string{}
+	}()
+
+	type testCase struct {
+		description string
+		err         error
+		target      map[string]interface{}
+	}
+	testCases := []testCase{
+		{
+			description: "cerror.Error",
+			err:         NewError(EcodeNotExists, "/a/b"),
+			target: map[string]interface{}{
+				"type":     "about:blank",
+				"title":    "Target is not exists",
+				"status":   float64(http.StatusNotFound),
+				"detail":   "/a/b",
+				"instance": "/v1/keys/a/b",
+				"code":     float64(EcodeNotExists),
+			},
+		},
+		{
+			description: "cerror.Error without message",
+			err:         NewError(EcodeUnknown, "backend is down"),
+			target: map[string]interface{}{
+				"type":     "about:blank",
+				"title":    "Service Unavailable",
+				"status":   float64(http.StatusServiceUnavailable),
+				"detail":   "backend is down",
+				"instance": "/v1/keys/a/b",
+				"code":     float64(EcodeUnknown),
+			},
+		},
+		{
+			description: "wrapped cerror.Error with default status",
+			err:         fmt.Errorf("wrapped: %w", NewError(EcodeNotDir, "/a/b is file")),
+			target: map[string]interface{}{
+				"type":     "about:blank",
+				"title":    "Bad Request",
+				"status":   float64(http.StatusBadRequest),
+				"detail":   "/a/b is file",
+				"instance": "/v1/keys/a/b",
+				"code":     float64(EcodeNotDir),
+			},
+		},
+		{
+			description: "non-public cerror.Error",
+			err:         NewError(EcodeExists, "/a/b").WithPublic(false),
+			target: map[string]interface{}{
+				"type":     "about:blank",
+				"title":    "Conflict",
+				"status":   float64(http.StatusConflict),
+				"instance": "/v1/keys/a/b",
+				"code":     float64(EcodeExists),
+			},
+		},
+		{
+			description: "cerror.Error with PublicMessage",
+			err:         NewError(EcodeNotExists, "/a/b").WithPublicMessage("Key not found"),
+			target: map[string]interface{}{
+				"type":     "about:blank",
+				"title":    "Key not found",
+				"status":   float64(http.StatusNotFound),
+				"instance": "/v1/keys/a/b",
+				"code":     float64(EcodeNotExists),
+			},
+		},
+		{
+			description: "unknown error",
+			err:         errors.New("disk is full"),
+			target: map[string]interface{}{
+				"type":     "about:blank",
+				"title":    "Internal Server Error",
+				"status":   float64(http.StatusInternalServerError),
+				"instance": "/v1/keys/a/b",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		actual := map[string]interface{}{}
+		s.NoError(json.Unmarshal(ProblemJSON(tc.err, "/v1/keys/a/b"), &actual), tc.description)
+		s.Equal(tc.target, actual, tc.description)
+	}
+}
+
+func (s *errorHTTPTestSuite) TestProblemJSONNil() {
+	s.Nil(ProblemJSON(nil, "/v1/keys/a/b"))
+}
+
+func (s *errorHTTPTestSuite) TestProblemJSONError() {
+	errorsMessage = map[int]string{
+		EcodeNotExists: `Target "a" is not exists`,
+	}
+	errorsStatus = map[int]int{
+		EcodeNotExists: http.StatusNotFound,
+	}
+	defer func() {
+		errorsMessage = map[int]string{}
+		marshal = json.Marshal
+	}()
+
+	type testCase struct {
+		description string
+		err         error
+		instance    string
+	}
+	testCases := []testCase{
+		{
+			description: "cerror.Error",
+			err:         NewError(EcodeNotExists, `/a/"b"`),
+			instance:    "/v1/keys/a/b",
+		},
+		{
+			description: "unknown error",
+			err:         errors.New("disk is full"),
+			instance:    "",
+		},
+	}
+	for _, tc := range testCases {
+		target := ProblemJSON(tc.err, tc.instance)
+
+		marshal = func(interface{}) ([]byte, error) {
+			return nil, errors.New("Error Marshal failed")
+		}
+		actual := ProblemJSON(tc.err, tc.instance)
+		marshal = json.Marshal
+
+		s.JSONEq(string(target), string(actual), tc.description)
+	}
 }
 
 func (s *errorTestSuite) TestSetErrorStatusReplace() {
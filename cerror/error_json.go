@@ -0,0 +1,180 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errorEntry is the error define in the array document of LoadErrorsMessageJSON
+type errorEntry struct {
+	Code    *int   `json:"code"`
+	Message string `json:"message"`
+	Name    string `json:"name,omitempty"`
+	HTTP    int    `json:"http,omitempty"`
+}
+
+// LoadErrorsMessageJSON init the error defines from JSON data, the data is one of:
+//
+//	{"10000001": "Target is Not File", ...}
+//	[{"code": 10000001, "message": "Target is Not File", "name": "EcodeNotFile", "http": 400}, ...]
+//
+// The name and http of array document are optional, they are registered by
+// RegisterCodeName and SetErrorsStatus. The code must be unique in the data. Nothing
+// is registered if the data has any malformed entry, and the returned error enumerates
+// them.
+func LoadErrorsMessageJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		return loadErrorsMessageObject(data)
+	}
+
+	return loadErrorsMessageArray(data)
+}
+
+// objectEntry is the key and value of the object document of LoadErrorsMessageJSON
+type objectEntry struct {
+	key   string
+	value string
+}
+
+// decodeObject decodes the object document into entries in document order, the
+// repeated keys are kept (json.Unmarshal into map keeps the last one only)
+func decodeObject(data []byte) ([]objectEntry, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	entries := []objectEntry{}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		entry := objectEntry{
+			key: t.(string),
+		}
+		if err := dec.Decode(&entry.value); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the document")
+	}
+	return entries, nil
+}
+
+func loadErrorsMessageObject(data []byte) error {
+	entries, err := decodeObject(data)
+	if err != nil {
+		return fmt.Errorf("invalid errors message document: %v", err)
+	}
+
+	// validate in key order, so the reported entries don't depend on document order
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	message := make(map[int]string, len(entries))
+	seen := map[int]struct{}{}
+	malformed := []string{}
+	for _, entry := range entries {
+		code, err := strconv.Atoi(entry.key)
+		if err != nil {
+			malformed = append(malformed, fmt.Sprintf("code %q is not integer", entry.key))
+			continue
+		}
+		if _, ok := seen[code]; ok {
+			malformed = append(malformed, fmt.Sprintf("code %d is duplicated", code))
+			continue
+		}
+		seen[code] = struct{}{}
+
+		if entry.value == "" {
+			malformed = append(malformed, fmt.Sprintf("code %d has empty message", code))
+			continue
+		}
+		message[code] = entry.value
+	}
+	if len(malformed) > 0 {
+		return malformedError(malformed)
+	}
+
+	SetErrorsMessage(message)
+	return nil
+}
+
+func loadErrorsMessageArray(data []byte) error {
+	document := []errorEntry{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("invalid errors message document: %v", err)
+	}
+
+	malformed := []string{}
+	seen := map[int]int{}
+	for i, entry := range document {
+		if entry.Code != nil {
+			if first, ok := seen[*entry.Code]; ok {
+				malformed = append(malformed, fmt.Sprintf("entry %d duplicates code %d of entry %d", i, *entry.Code, first))
+				continue
+			}
+			seen[*entry.Code] = i
+		}
+
+		switch {
+		case entry.Code == nil:
+			malformed = append(malformed, fmt.Sprintf("entry %d has no code", i))
+		case entry.Message == "":
+			malformed = append(malformed, fmt.Sprintf("entry %d with code %d has empty message", i, *entry.Code))
+		case entry.HTTP != 0 && (entry.HTTP < 100 || entry.HTTP > 599):
+			malformed = append(malformed, fmt.Sprintf("entry %d with code %d has invalid http status %d", i, *entry.Code, entry.HTTP))
+		}
+	}
+	if len(malformed) > 0 {
+		return malformedError(malformed)
+	}
+
+	message := make(map[int]string, len(document))
+	status := map[int]int{}
+	for _, entry := range document {
+		message[*entry.Code] = entry.Message
+		if entry.Name != "" {
+			RegisterCodeName(*entry.Code, entry.Name)
+		}
+		if entry.HTTP != 0 {
+			status[*entry.Code] = entry.HTTP
+		}
+	}
+	SetErrorsMessage(message)
+	SetErrorsStatus(status)
+	return nil
+}
+
+func malformedError(malformed []string) error {
+	return fmt.Errorf("errors message document has %d malformed entries: %s", len(malformed), strings.Join(malformed, "; "))
+}
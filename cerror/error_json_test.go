@@ -0,0 +1,135 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type errorJSONTestSuite struct {
+	suite.Suite
+}
+
+func (s *errorJSONTestSuite) SetupTest() {
+	errorsMessage = map[int]string{}
+	errorsName = map[int]string{}
+	errorsStatus = map[int]int{}
+}
+
+func (s *errorJSONTestSuite) TearDownTest() {
+	s.SetupTest()
+}
+
+func (s *errorJSONTestSuite) TestLoadObject() {
+	err := LoadErrorsMessageJSON([]byte(fmt.Sprintf(` {"%d": "Target is Not File", "%d": "Target is Not Dir"}`, EcodeNotFile, EcodeNotDir)))
+	s.NoError(err)
+
+	s.Equal(map[int]string{
+		EcodeNotFile: "Target is Not File",
+		EcodeNotDir:  "Target is Not Dir",
+	}, errorsMessage)
+	s.Empty(errorsName)
+	s.Empty(errorsStatus)
+}
+
+func (s *errorJSONTestSuite) TestLoadArray() {
+	err := LoadErrorsMessageJSON([]byte(fmt.Sprintf(`[
+		{"code": %d, "message": "Target is Not File", "name": "EcodeNotFile", "http": 409},
+		{"code": %d, "message": "Target is Not Dir"}
+	]`, EcodeNotFile, EcodeNotDir)))
+	s.NoError(err)
+
+	s.Equal(map[int]string{
+		EcodeNotFile: "Target is Not File",
+		EcodeNotDir:  "Target is Not Dir",
+	}, errorsMessage)
+	s.Equal(map[int]string{
+		EcodeNotFile: "EcodeNotFile",
+	}, errorsName)
+	s.Equal(map[int]int{
+		EcodeNotFile: 409,
+	}, errorsStatus)
+}
+
+func (s *errorJSONTestSuite) TestLoadFailed() {
+	type testCase struct {
+		description string
+		data        string
+		target      string
+	}
+	testCases := []testCase{
+		{
+			description: "invalid json",
+			data:        `{"1": `,
+			target:      "invalid errors message document",
+		},
+		{
+			description: "invalid document type",
+			data:        `"message"`,
+			target:      "invalid errors message document",
+		},
+		{
+			description: "object with non integer code",
+			data:        `{"1": "one", "two": "two", "3": ""}`,
+			target:      `errors message document has 2 malformed entries: code 3 has empty message; code "two" is not integer`,
+		},
+		{
+			description: "array with malformed entries",
+			data:        `[{"code": 1, "message": "one"}, {"message": "two"}, {"code": 3}, {"code": 4, "message": "four", "http": 999}]`,
+			target:      "errors message document has 3 malformed entries: entry 1 has no code; entry 2 with code 3 has empty message; entry 3 with code 4 has invalid http status 999",
+		},
+		{
+			description: "object with duplicate code",
+			data:        `{"1": "one", "01": "one again"}`,
+			target:      "errors message document has 1 malformed entries: code 1 is duplicated",
+		},
+		{
+			description: "object with repeated key",
+			data:        `{"1": "a", "1": "b"}`,
+			target:      "errors message document has 1 malformed entries: code 1 is duplicated",
+		},
+		{
+			description: "object with duplicate code and empty message",
+			data:        `{"2": "", "02": "x"}`,
+			target:      "errors message document has 1 malformed entries: code 2 is duplicated",
+		},
+		{
+			description: "object with trailing data",
+			data:        `{"1": "a"} {}`,
+			target:      "invalid errors message document: unexpected data after the document",
+		},
+		{
+			description: "array with duplicate code",
+			data:        `[{"code": 1, "message": "one", "name": "One"}, {"code": 2, "message": "two"}, {"code": 1, "message": "one again", "name": "OneAgain"}]`,
+			target:      "errors message document has 1 malformed entries: entry 2 duplicates code 1 of entry 0",
+		},
+	}
+	for _, tc := range testCases {
+		err := LoadErrorsMessageJSON([]byte(tc.data))
+		s.Error(err, tc.description)
+		s.Contains(err.Error(), tc.target, tc.description)
+		s.Empty(errorsMessage, tc.description)
+		s.Empty(errorsName, tc.description)
+		s.Empty(errorsStatus, tc.description)
+	}
+}
+
+func TestErrorJSONTestSuite(t *testing.T) {
+	s := &errorJSONTestSuite{}
+	suite.Run(t, s)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+var errorsName = map[int]string{}
+
+// RegisterCodeName register the symbolic name of errorCode, such as "EcodeNotDir".
+// Register the same errorCode again will replace the name.
+func RegisterCodeName(errorCode int, name string) {
+	errorsName[errorCode] = name
+	recordRegistration(registeredNames, errorCode, name)
+}
+
+// NameOf returns the symbolic name of errorCode and whether it is registered
+func NameOf(errorCode int) (string, bool) {
+	name, ok := errorsName[errorCode]
+	return name, ok
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type errorNameTestSuite struct {
+	suite.Suite
+}
+
+func (s *errorNameTestSuite) SetupTest() {
+	errorsName = map[int]string{}
+}
+
+func (s *errorNameTestSuite) TearDownTest() {
+	errorsName = map[int]string{}
+}
+
+func (s *errorNameTestSuite) TestNameOfFind() {
+	RegisterCodeName(EcodeNotDir, "EcodeNotDir")
+
+	name, ok := NameOf(EcodeNotDir)
+	s.True(ok)
+	s.Equal("EcodeNotDir", name)
+}
+
+func (s *errorNameTestSuite) TestNameOfNotFind() {
+	name, ok := NameOf(EcodeNotDir)
+	s.False(ok)
+	s.Equal("", name)
+}
+
+func (s *errorNameTestSuite) TestRegisterCodeNameReplace() {
+	RegisterCodeName(EcodeNotDir, "EcodeNotDir")
+	RegisterCodeName(EcodeNotDir, "EcodeNotDirectory")
+
+	name, ok := NameOf(EcodeNotDir)
+	s.True(ok)
+	s.Equal("EcodeNotDirectory", name)
+}
+
+func TestErrorNameTestSuite(t *testing.T) {
+	s := &errorNameTestSuite{}
+	suite.Run(t, s)
+}
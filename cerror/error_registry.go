@@ -0,0 +1,108 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// registeredMessages and registeredNames record every distinct value registered for
+// each errorCode, CheckRegistry uses them to find the conflicts
+var (
+	registeredMessages = map[int][]string{}
+	registeredNames    = map[int][]string{}
+)
+
+func recordRegistration(registered map[int][]string, errorCode int, value string) {
+	for _, v := range registered[errorCode] {
+		if v == value {
+			return
+		}
+	}
+	registered[errorCode] = append(registered[errorCode], value)
+}
+
+func findConflicts(kind string, registered map[int][]string) []string {
+	codes := make([]int, 0, len(registered))
+	for code, values := range registered {
+		if len(values) > 1 {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+
+	conflicts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		conflicts = append(conflicts, fmt.Sprintf("errorCode %d has conflicting %ss %q", code, kind, registered[code]))
+	}
+	return conflicts
+}
+
+func findNameConflicts() []string {
+	codes := map[string][]int{}
+	for code, name := range errorsName {
+		codes[name] = append(codes[name], code)
+	}
+
+	names := make([]string, 0, len(codes))
+	for name, c := range codes {
+		if len(c) > 1 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	conflicts := make([]string, 0, len(names))
+	for _, name := range names {
+		sort.Ints(codes[name])
+		conflicts = append(conflicts, fmt.Sprintf("name %q is used by errorCodes %v", name, codes[name]))
+	}
+	return conflicts
+}
+
+// ResetRegistryHistory forgets the earlier registrations and keeps the current
+// messages and names only. Call it after an intentional override by a later
+// SetErrorsMessage or RegisterCodeName, or after reloading a changed catalog, so
+// CheckRegistry doesn't report them.
+func ResetRegistryHistory() {
+	registeredMessages = map[int][]string{}
+	for k, v := range errorsMessage {
+		registeredMessages[k] = []string{v}
+	}
+
+	registeredNames = map[int][]string{}
+	for k, v := range errorsName {
+		registeredNames[k] = []string{v}
+	}
+}
+
+// CheckRegistry validates that no errorCode is registered with conflicting messages
+// or names, and no name is shared by different errorCodes. Every registration since
+// start (or the last ResetRegistryHistory) counts, so an errorCode overridden with a
+// different message is reported even though the last writer wins. It's intended to be
+// called in main or tests after all packages are initialized, the returned error
+// enumerates every conflict found. The errorCode ranges of Codespace aren't checked.
+func CheckRegistry() error {
+	conflicts := findConflicts("message", registeredMessages)
+	conflicts = append(conflicts, findConflicts("name", registeredNames)...)
+	conflicts = append(conflicts, findNameConflicts()...)
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("cerror registry has %d conflicts: %s", len(conflicts), strings.Join(conflicts, "; "))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type errorRegistryTestSuite struct {
+	suite.Suite
+}
+
+func (s *errorRegistryTestSuite) SetupTest() {
+	errorsMessage = map[int]string{}
+	errorsName = map[int]string{}
+	registeredMessages = map[int][]string{}
+	registeredNames = map[int][]string{}
+}
+
+func (s *errorRegistryTestSuite) TearDownTest() {
+	s.SetupTest()
+}
+
+func (s *errorRegistryTestSuite) TestCheckRegistryOk() {
+	SetErrorsMessage(templateError)
+	SetErrorsMessage(templateError)
+	RegisterCodeName(EcodeNotDir, "EcodeNotDir")
+	RegisterCodeName(EcodeNotFile, "EcodeNotFile")
+
+	s.NoError(CheckRegistry())
+}
+
+func (s *errorRegistryTestSuite) TestCheckRegistryMessageConflict() {
+	SetErrorsMessage(templateError)
+	SetErrorsMessage(map[int]string{
+		EcodeNotDir: "Other Not Dir",
+	})
+
+	err := CheckRegistry()
+	s.Error(err)
+	s.Contains(err.Error(), fmt.Sprintf("errorCode %d has conflicting messages", EcodeNotDir))
+	s.Contains(err.Error(), "Other Not Dir")
+}
+
+func (s *errorRegistryTestSuite) TestCheckRegistryNameConflict() {
+	RegisterCodeName(EcodeNotDir, "EcodeNotDir")
+	RegisterCodeName(EcodeNotDir, "EcodeNotDirectory")
+	RegisterCodeName(EcodeNotFile, "EcodeNotFile")
+	RegisterCodeName(EcodeExists, "EcodeNotFile")
+
+	err := CheckRegistry()
+	s.Error(err)
+	s.Contains(err.Error(), "has 2 conflicts")
+	s.Contains(err.Error(), fmt.Sprintf("errorCode %d has conflicting names", EcodeNotDir))
+	s.Contains(err.Error(), fmt.Sprintf(`name "EcodeNotFile" is used by errorCodes [%d %d]`, EcodeNotFile, EcodeExists))
+}
+
+func (s *errorRegistryTestSuite) TestResetRegistryHistory() {
+	SetErrorsMessage(templateError)
+	SetErrorsMessage(map[int]string{
+		EcodeNotDir: "Other Not Dir",
+	})
+	RegisterCodeName(EcodeNotDir, "EcodeNotDir")
+	RegisterCodeName(EcodeNotDir, "EcodeNotDirectory")
+	s.Error(CheckRegistry())
+
+	ResetRegistryHistory()
+	s.NoError(CheckRegistry())
+	s.Equal("Other Not Dir", errorsMessage[EcodeNotDir])
+	s.Equal("EcodeNotDirectory", errorsName[EcodeNotDir])
+
+	SetErrorsMessage(map[int]string{
+		EcodeNotDir: "Target is Not Dir",
+	})
+	s.Error(CheckRegistry())
+}
+
+func TestErrorRegistryTestSuite(t *testing.T) {
+	s := &errorRegistryTestSuite{}
+	suite.Run(t, s)
+}
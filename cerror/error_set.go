@@ -0,0 +1,54 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+import (
+	"fmt"
+)
+
+// ErrorSet is the errors declared by a package, the messages are registered into the
+// global errors message
+type ErrorSet struct {
+	codes map[int]struct{}
+}
+
+// NewErrorSet construct a empty ErrorSet
+func NewErrorSet() *ErrorSet {
+	return &ErrorSet{
+		codes: map[int]struct{}{},
+	}
+}
+
+// Define register the errorCode and message, and returns the constructor of Error
+// with the errorCode. It panics if the errorCode is already defined in the set.
+func (s *ErrorSet) Define(errorCode int, message string) func(cause string) *Error {
+	if _, ok := s.codes[errorCode]; ok {
+		panic(fmt.Sprintf("ErrorSet Define: errorCode %d is already defined", errorCode))
+	}
+	s.codes[errorCode] = struct{}{}
+
+	SetErrorsMessage(map[int]string{
+		errorCode: message,
+	})
+	return func(cause string) *Error {
+		return NewError(errorCode, cause)
+	}
+}
+
+// Has check the errorCode is defined in the set
+func (s *ErrorSet) Has(errorCode int) bool {
+	_, ok := s.codes[errorCode]
+	return ok
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cerror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type errorSetTestSuite struct {
+	suite.Suite
+
+	set *ErrorSet
+}
+
+func (s *errorSetTestSuite) SetupTest() {
+	errorsMessage = map[int]string{}
+	s.set = NewErrorSet()
+}
+
+func (s *errorSetTestSuite) TearDownTest() {
+	errorsMessage = map[int]string{}
+}
+
+func (s *errorSetTestSuite) TestDefine() {
+	errNotDir := s.set.Define(EcodeNotDir, "Target is Not Dir")
+	s.Equal("Target is Not Dir", errorsMessage[EcodeNotDir])
+	s.True(s.set.Has(EcodeNotDir))
+	s.False(s.set.Has(EcodeNotFile))
+
+	e := errNotDir("/a/b")
+	s.Equal(EcodeNotDir, e.ErrorCode)
+	s.Equal("Target is Not Dir", e.Message)
+	s.Equal("/a/b", e.Cause)
+	s.True(Is(e, EcodeNotDir))
+}
+
+func (s *errorSetTestSuite) TestDefineDuplicate() {
+	s.set.Define(EcodeNotDir, "Target is Not Dir")
+	s.Panics(func() {
+		s.set.Define(EcodeNotDir, "Target is Not Directory")
+	})
+	s.Equal("Target is Not Dir", errorsMessage[EcodeNotDir])
+
+	NewErrorSet().Define(EcodeNotDir, "Target is Not Directory")
+	s.Equal("Target is Not Directory", errorsMessage[EcodeNotDir])
+}
+
+func TestErrorSetTestSuite(t *testing.T) {
+	s := &errorSetTestSuite{}
+	suite.Run(t, s)
+}
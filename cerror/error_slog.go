@@ -0,0 +1,48 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+// +build go1.21
+
+package cerror
+
+import (
+	"log/slog"
+)
+
+// LogValue is for the slog.LogValuer interface, the Error is rendered as a group
+func (e Error) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Int("errorCode", e.ErrorCode),
+		slog.String("message", e.Message),
+	}
+	if e.Cause != "" {
+		attrs = append(attrs, slog.String("cause", e.Cause))
+	}
+	if !e.Time.IsZero() {
+		attrs = append(attrs, slog.Time("time", e.Time))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// ErrAttr returns the slog.Attr with key "error" for err, the Error in err's chain
+// is rendered as a group and other errors are rendered as string
+func ErrAttr(err error) slog.Attr {
+	if e, ok := AsError(err); ok {
+		return slog.Any("error", e)
+	}
+
+	return slog.Any("error", err)
+}
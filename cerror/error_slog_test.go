@@ -0,0 +1,111 @@
+// Copyright (c) 2018 soren yang
+//
+// Licensed under the MIT License
+// you may not use this file except in complicance with the License.
+// You may obtain a copy of the License at
+//
+//     https://opensource.org/licenses/MIT
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+// +build go1.21
+
+package cerror
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type errorSlogTestSuite struct {
+	suite.Suite
+
+	buf    *bytes.Buffer
+	logger *slog.Logger
+}
+
+func (s *errorSlogTestSuite) SetupTest() {
+	errorsMessage = templateError
+	SetClock(nil)
+
+	s.buf = &bytes.Buffer{}
+	s.logger = slog.New(slog.NewTextHandler(s.buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func (s *errorSlogTestSuite) TearDownTest() {
+	errorsMessage = map[int]string{}
+	SetClock(nil)
+}
+
+func (s *errorSlogTestSuite) TestLogValue() {
+	s.logger.Info("test", "err", NewError(EcodeNotDir, "TestLogValue"))
+	s.Equal(
+		fmt.Sprintf("level=INFO msg=test err.errorCode=%d err.message=\"Target is Not Dir\" err.cause=TestLogValue\n", EcodeNotDir),
+		s.buf.String(),
+	)
+}
+
+func (s *errorSlogTestSuite) TestLogValueTime() {
+	SetClock(func() time.Time {
+		return templateTime
+	})
+
+	s.logger.Info("test", "err", NewError(EcodeNotDir, ""))
+	s.Equal(
+		fmt.Sprintf("level=INFO msg=test err.errorCode=%d err.message=\"Target is Not Dir\" err.time=2018-01-02T03:04:05.000Z\n", EcodeNotDir),
+		s.buf.String(),
+	)
+}
+
+func (s *errorSlogTestSuite) TestErrAttr() {
+	type testCase struct {
+		description string
+		err         error
+		target      string
+	}
+	testCases := []testCase{
+		{
+			description: "cerror.Error",
+			err:         NewError(EcodeNotDir, "TestErrAttr"),
+			target:      fmt.Sprintf("level=INFO msg=test error.errorCode=%d error.message=\"Target is Not Dir\" error.cause=TestErrAttr\n", EcodeNotDir),
+		},
+		{
+			description: "wrapped cerror.Error",
+			err:         fmt.Errorf("wrapped: %w", NewError(EcodeNotDir, "TestErrAttr")),
+			target:      fmt.Sprintf("level=INFO msg=test error.errorCode=%d error.message=\"Target is Not Dir\" error.cause=TestErrAttr\n", EcodeNotDir),
+		},
+		{
+			description: "other error",
+			err:         errors.New("plain"),
+			target:      "level=INFO msg=test error=plain\n",
+		},
+	}
+	for _, tc := range testCases {
+		s.buf.Reset()
+		s.logger.Info("test", ErrAttr(tc.err))
+		s.Equal(tc.target, s.buf.String(), tc.description)
+	}
+}
+
+func TestErrorSlogTestSuite(t *testing.T) {
+	s := &errorSlogTestSuite{}
+	suite.Run(t, s)
+}
@@ -18,7 +18,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -50,12 +53,19 @@ var templateError = map[int]string{
 	EcodeExists:    "Target is exists",
 }
 
+var templateTime = time.Date(2018, 1, 2, 3, 4, 5, 6, time.UTC)
+
 func (s *errorTestSuite) SetupTest() {
 	errorsMessage = templateError
+	SetClock(func() time.Time {
+		return templateTime
+	})
 }
 
 func (s *errorTestSuite) TearDownTest() {
 	errorsMessage = map[int]string{}
+	errorsPublicMessage = map[int]string{}
+	SetClock(nil)
 }
 
 func (s *errorTestSuite) TestNewError() {
@@ -67,6 +77,31 @@ func (s *errorTestSuite) TestNewError() {
 	}
 }
 
+func (s *errorTestSuite) TestNewErrorTime() {
+	e := NewError(EcodeNotDir, "TestNewErrorTime")
+	s.Equal(templateTime, e.Time)
+
+	e = Newf(EcodeNotDir, "TestNewErrorTime")
+	s.Equal(templateTime, e.Time)
+
+	SetClock(nil)
+	e = NewError(EcodeNotDir, "TestNewErrorTime")
+	s.True(e.Time.IsZero())
+}
+
+func (s *errorTestSuite) TestWithTime() {
+	e := NewError(EcodeNotDir, "TestWithTime")
+
+	e1 := e.WithTime(time.Time{})
+	s.Equal(templateTime, e.Time)
+	s.True(e1.Time.IsZero())
+	s.NotContains(e1.JSONString(), "time")
+
+	SetClock(nil)
+	e = NewError(EcodeNotDir, "TestWithTime").WithTime(templateTime)
+	s.Contains(e.JSONString(), `"time":"2018-01-02T03:04:05Z"`)
+}
+
 func (s *errorTestSuite) TestNewErrorUnkownCode() {
 	code := 0
 	cause := "Unknown"
@@ -77,7 +112,124 @@ func (s *errorTestSuite) TestNewErrorUnkownCode() {
 	s.Equal(cause, e.Cause)
 }
 
+func (s *errorTestSuite) TestNewf() {
+	e := Newf(EcodeNotDir, "key %s is %d", "/a", 1)
+	s.Equal(EcodeNotDir, e.ErrorCode)
+	s.Equal("key /a is 1", e.Message)
+	s.Equal("", e.Cause)
+	s.Equal("Target is Not Dir", errorsMessage[EcodeNotDir])
+
+	e = Newf(0, "unregistered")
+	s.Equal(0, e.ErrorCode)
+	s.Equal("unregistered", e.Message)
+	_, ok := errorsMessage[0]
+	s.False(ok)
+}
+
+func (s *errorTestSuite) TestAppendCause() {
+	e := NewError(EcodeNotDir, "")
+
+	e1 := e.AppendCause(": ", "open /a")
+	s.Equal("", e.Cause)
+	s.Equal("open /a", e1.Cause)
+
+	e2 := e1.AppendCause(": ", "read /a/b")
+	s.Equal("open /a", e1.Cause)
+	s.Equal("open /a: read /a/b", e2.Cause)
+	s.Equal(EcodeNotDir, e2.ErrorCode)
+	s.Equal("Target is Not Dir (open /a: read /a/b)", e2.Error())
+}
+
+func (s *errorTestSuite) TestToError() {
+	var e *Error
+	var err error = e
+	s.True(nil != err)
+	s.True(nil == e.ToError())
+
+	e = NewError(EcodeNotDir, "TestToError")
+	s.Equal(error(e), e.ToError())
+}
+
+func (s *errorTestSuite) TestFormat() {
+	type testCase struct {
+		description string
+		format      string
+		target      string
+	}
+	e := NewError(EcodeNotDir, "TestFormat")
+	testCases := []testCase{
+		{
+			description: "format with s",
+			format:      "%s",
+			target:      "Target is Not Dir (TestFormat)",
+		},
+		{
+			description: "format with v",
+			format:      "%v",
+			target:      "Target is Not Dir (TestFormat)",
+		},
+		{
+			description: "format with +v",
+			format:      "%+v",
+			target:      fmt.Sprintf("[%d] Target is Not Dir (TestFormat)", EcodeNotDir),
+		},
+		{
+			description: "format with q",
+			format:      "%q",
+			target:      `"Target is Not Dir (TestFormat)"`,
+		},
+		{
+			description: "format with +q",
+			format:      "%+q",
+			target:      `"Target is Not Dir (TestFormat)"`,
+		},
+		{
+			description: "format with width",
+			format:      "[%34v]",
+			target:      "[    Target is Not Dir (TestFormat)]",
+		},
+		{
+			description: "format with left-justified width",
+			format:      "[%-34s]",
+			target:      "[Target is Not Dir (TestFormat)    ]",
+		},
+		{
+			description: "format with precision",
+			format:      "[%.6s]",
+			target:      "[Target]",
+		},
+		{
+			description: "format with width and precision",
+			format:      "[%8.3q]",
+			target:      `[   "Tar"]`,
+		},
+		{
+			description: "format with x",
+			format:      "%.6x",
+			target:      "546172676574",
+		},
+		{
+			description: "format with X",
+			format:      "% .6X",
+			target:      "54 61 72 67 65 74",
+		},
+		{
+			description: "format with #v",
+			format:      "%#v",
+			target:      fmt.Sprintf(`cerror.Error{ErrorCode:%d, Message:"Target is Not Dir", Cause:"TestFormat", Time:time.Date(2018, time.January, 2, 3, 4, 5, 6, time.UTC), PublicMessage:"", private:false, origin:error(nil)}`, EcodeNotDir),
+		},
+	}
+	for _, tc := range testCases {
+		s.Equal(tc.target, fmt.Sprintf(tc.format, e), tc.description)
+		s.Equal(tc.target, fmt.Sprintf(tc.format, *e), tc.description)
+	}
+
+	e = &Error{ErrorCode: 1, Cause: "c"}
+	s.Equal("{1 %!d(string=) %!d(string=c) {0 0 0} %!d(string=) %!d(bool=false) <nil>}", fmt.Sprintf("%d", e))
+}
+
 func (s *errorTestSuite) TestJSONString() {
+	SetClock(nil)
 	e := NewError(EcodeNotDir, "TestJSONString")
 	str := e.JSONString()
 
@@ -86,6 +238,35 @@ func (s *errorTestSuite) TestJSONString() {
 	s.Equal(string(str2), str)
 }
 
+func (s *errorTestSuite) TestJSONEmbedded() {
+	v := struct {
+		Error
+		Extra string `json:"extra"`
+	}{
+		Error: *NewError(EcodeNotDir, "TestJSONEmbedded"),
+		Extra: "extra",
+	}
+
+	b, err := json.Marshal(v)
+	s.NoError(err)
+	s.Equal(fmt.Sprintf(`{"errorCode":%d,"message":"Target is Not Dir","cause":"TestJSONEmbedded","extra":"extra"}`, EcodeNotDir), string(b))
+}
+
+func (s *errorTestSuite) TestJSONStringTime() {
+	e := NewError(EcodeNotDir, "TestJSONStringTime")
+	s.Equal(
+		fmt.Sprintf(`{"errorCode":%d,"message":"Target is Not Dir","cause":"TestJSONStringTime","time":"2018-01-02T03:04:05Z"}`, EcodeNotDir),
+		e.JSONString(),
+	)
+
+	SetClock(nil)
+	e = NewError(EcodeNotDir, "TestJSONStringTime")
+	s.Equal(
+		fmt.Sprintf(`{"errorCode":%d,"message":"Target is Not Dir","cause":"TestJSONStringTime"}`, EcodeNotDir),
+		e.JSONString(),
+	)
+}
+
 func (s *errorTestSuite) TestJSONStringError() {
 	marshal = func(interface{}) ([]byte, error) {
 		return nil, errors.New("Error Marshal failed")
@@ -95,13 +276,78 @@ func (s *errorTestSuite) TestJSONStringError() {
 	}()
 
 	e := NewError(EcodeNotDir, "TestJSONString")
+	s.Equal(
+		fmt.Sprintf(`{"errorCode":%d,"message":"Target is Not Dir","cause":"TestJSONString","time":"2018-01-02T03:04:05Z"}`, EcodeNotDir),
+		e.JSONString(),
+	)
+
+	SetClock(nil)
+	e = NewError(EcodeNotDir, "TestJSONString")
 	str := e.JSONString()
 
-	str2, err2 := json.Marshal(e)
-	s.NoError(err2)
+	str2, err := json.Marshal(e)
+	s.NoError(err)
 	s.Equal(string(str2), str)
 }
 
+func (s *errorTestSuite) TestWithPublic() {
+	e := NewError(EcodeNotDir, "TestWithPublic")
+	s.True(e.IsPublic())
+
+	e2 := e.WithPublic(false)
+	s.True(e.IsPublic())
+	s.False(e2.IsPublic())
+	s.True(e2.WithPublic(true).IsPublic())
+}
+
+func (s *errorTestSuite) TestJSONStringPublic() {
+	e := NewError(EcodeNotDir, "TestJSONStringPublic")
+	s.Equal(e.JSONString(), e.JSONStringPublic())
+
+	e = e.WithPublic(false)
+	s.Equal(fmt.Sprintf(`{"errorCode":%d}`, EcodeNotDir), e.JSONStringPublic())
+	s.Equal(NewError(EcodeNotDir, "TestJSONStringPublic").JSONString(), e.JSONString())
+}
+
+func (s *errorTestSuite) TestJSONStringPublicMessage() {
+	e := NewError(EcodeNotDir, "/a/b is file").WithPublicMessage("Invalid path")
+	s.Equal("Invalid path", e.PublicMessage)
+	s.Equal(fmt.Sprintf(`{"errorCode":%d,"message":"Invalid path"}`, EcodeNotDir), e.JSONStringPublic())
+	s.Equal(fmt.Sprintf(`{"errorCode":%d,"message":"Invalid path"}`, EcodeNotDir), e.WithPublic(false).JSONStringPublic())
+	s.Contains(e.JSONString(), "/a/b is file")
+	s.NotContains(e.JSONString(), "Invalid path")
+}
+
+func (s *errorTestSuite) TestJSONStringPublicGenericMessage() {
+	SetErrorsPublicMessage(map[int]string{
+		EcodeNotDir: "Bad Request",
+	})
+
+	e := NewError(EcodeNotDir, "/a/b is file").WithPublic(false)
+	s.Equal(fmt.Sprintf(`{"errorCode":%d,"message":"Bad Request"}`, EcodeNotDir), e.JSONStringPublic())
+
+	e = e.WithPublicMessage("Invalid path")
+	s.Equal(fmt.Sprintf(`{"errorCode":%d,"message":"Invalid path"}`, EcodeNotDir), e.JSONStringPublic())
+
+	e = NewError(EcodeNotDir, "/a/b is file")
+	s.Equal(e.JSONString(), e.JSONStringPublic())
+}
+
+func (s *errorTestSuite) TestJSONStringPublicError() {
+	marshal = func(interface{}) ([]byte, error) {
+		return nil, errors.New("Error Marshal failed")
+	}
+	defer func() {
+		marshal = json.Marshal
+	}()
+
+	e := NewError(EcodeNotDir, "TestJSONStringPublic").WithPublic(false)
+	s.Equal(fmt.Sprintf(`{"errorCode":%d}`, EcodeNotDir), e.JSONStringPublic())
+
+	e = e.WithPublicMessage("Invalid path")
+	s.Equal(fmt.Sprintf(`{"errorCode":%d,"message":"Invalid path"}`, EcodeNotDir), e.JSONStringPublic())
+}
+
 func (s *errorTestSuite) TestSetErrorMessageOK() {
 	errorsMessage = map[int]string{}
 	SetErrorsMessage(templateError)
@@ -143,6 +389,28 @@ func (s *errorTestSuite) TestSetErrorMessageReplace() {
 	}
 }
 
+func (s *errorTestSuite) TestSetErrorMessageLastWriterWins() {
+	errorsMessage = map[int]string{}
+
+	first := map[int]string{
+		100:         "first 100",
+		EcodeNotDir: "first EcodeNotDir",
+	}
+	second := map[int]string{
+		EcodeNotDir: "second EcodeNotDir",
+	}
+	SetErrorsMessage(first)
+	SetErrorsMessage(second)
+	s.Equal("first 100", errorsMessage[100])
+	s.Equal("second EcodeNotDir", errorsMessage[EcodeNotDir])
+
+	SetErrorsMessage(first)
+	s.Equal("first EcodeNotDir", errorsMessage[EcodeNotDir])
+
+	SetErrorsMessage(second)
+	s.Equal("second EcodeNotDir", errorsMessage[EcodeNotDir])
+}
+
 func (s *errorTestSuite) TestIsOk() {
 	type testCase struct {
 		description string
@@ -267,6 +535,164 @@ func (s *errorTestSuite) TestIsErrorFailed() {
 	}
 }
 
+func (s *errorTestSuite) TestAsErrorOk() {
+	e := NewError(EcodeNotDir, "TestAsError")
+
+	actual, ok := AsError(e)
+	s.True(ok)
+	s.Equal(e, actual)
+
+	actual, ok = AsError(fmt.Errorf("wrapped: %w", e))
+	s.True(ok)
+	s.Equal(e, actual)
+
+	actual, ok = AsError(fmt.Errorf("twice: %w", fmt.Errorf("wrapped: %w", e)))
+	s.True(ok)
+	s.Equal(e, actual)
+}
+
+func (s *errorTestSuite) TestAsErrorFailed() {
+	type testCase struct {
+		description string
+		err         error
+	}
+	var err *Error
+	testCases := []testCase{
+		{
+			description: "nil error failed",
+			err:         nil,
+		},
+		{
+			description: "nil error value failed",
+			err:         err,
+		},
+		{
+			description: "error type match failed",
+			err:         fmt.Errorf(""),
+		},
+		{
+			description: "wrapped error type match failed",
+			err:         fmt.Errorf("wrapped: %w", errors.New("")),
+		},
+	}
+	for _, tc := range testCases {
+		actual, ok := AsError(tc.err)
+		s.False(ok, tc.description)
+		s.Nil(actual, tc.description)
+	}
+}
+
+func (s *errorTestSuite) TestFromErrorOk() {
+	e := NewError(EcodeNotDir, "TestFromError")
+
+	actual, err := FromError(e)
+	s.NoError(err)
+	s.Equal(e, actual)
+
+	actual, err = FromError(fmt.Errorf("wrapped: %w", e))
+	s.NoError(err)
+	s.Equal(e, actual)
+
+	actual, err = FromError(nil)
+	s.NoError(err)
+	s.Nil(actual)
+}
+
+func (s *errorTestSuite) TestFromErrorFailed() {
+	type testCase struct {
+		description string
+		err         error
+		target      string
+	}
+	var err *Error
+	testCases := []testCase{
+		{
+			description: "nil error value failed",
+			err:         err,
+			target:      "not a cerror.Error: <nil>",
+		},
+		{
+			description: "error type match failed",
+			err:         errors.New("plain"),
+			target:      "not a cerror.Error: plain",
+		},
+	}
+	for _, tc := range testCases {
+		actual, err := FromError(tc.err)
+		s.Nil(actual, tc.description)
+		s.True(errors.Is(err, ErrNotCError), tc.description)
+		s.Equal(tc.target, err.Error(), tc.description)
+	}
+}
+
+func (s *errorTestSuite) TestUnwrap() {
+	s.Nil(NewError(EcodeNotDir, "TestUnwrap").Unwrap())
+}
+
+func (s *errorTestSuite) TestSanitize() {
+	type testCase struct {
+		description string
+		err         error
+		target      int
+	}
+	mapping := map[int]int{
+		EcodeNotDir:  EcodeNotExists,
+		EcodeNotFile: EcodeNotExists,
+	}
+	testCases := []testCase{
+		{
+			description: "mapped errorCode",
+			err:         NewError(EcodeNotDir, "/a/b is file"),
+			target:      EcodeNotExists,
+		},
+		{
+			description: "wrapped mapped errorCode",
+			err:         fmt.Errorf("wrapped: %w", NewError(EcodeNotFile, "/a/b is dir")),
+			target:      EcodeNotExists,
+		},
+		{
+			description: "unmapped errorCode",
+			err:         NewError(EcodeExists, "/a/b exists"),
+			target:      EcodeUnknown,
+		},
+		{
+			description: "non Error",
+			err:         errors.New("disk is full"),
+			target:      EcodeUnknown,
+		},
+	}
+	for _, tc := range testCases {
+		e := Sanitize(tc.err, mapping, EcodeUnknown)
+		s.Equal(tc.target, e.ErrorCode, tc.description)
+		s.Equal(errorsMessage[tc.target], e.Message, tc.description)
+		s.Equal("", e.Cause, tc.description)
+		s.Equal(tc.err, e.Unwrap(), tc.description)
+		s.True(errors.Is(e, tc.err), tc.description)
+		s.NotContains(e.JSONString(), "/a/b", tc.description)
+	}
+
+	s.Nil(Sanitize(nil, mapping, EcodeUnknown))
+
+	e := Sanitize(NewError(EcodeNotDir, "/a/b is file").WithPublicMessage("Invalid path").WithPublic(false), mapping, EcodeUnknown)
+	s.Equal("", e.Cause)
+	s.Equal("", e.PublicMessage)
+	s.True(e.IsPublic())
+}
+
+func (s *errorTestSuite) TestUnwrapNilError() {
+	var e *Error
+	s.Nil(e.Unwrap())
+
+	var pathErr *os.PathError
+	s.False(errors.Is(e, io.EOF))
+	s.False(errors.As(e, &pathErr))
+
+	sanitized := Sanitize(e, map[int]int{}, EcodeUnknown)
+	s.Equal(EcodeUnknown, sanitized.ErrorCode)
+	s.False(errors.Is(sanitized, io.EOF))
+	s.False(errors.As(sanitized, &pathErr))
+}
+
 func TestErrorTestSuite(t *testing.T) {
 	s := &errorTestSuite{}
 	suite.Run(t, s)